@@ -3,8 +3,11 @@ package bridge
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/thetronjohnson/layrr/pkg/claude"
+	"github.com/thetronjohnson/layrr/pkg/git"
 	"github.com/thetronjohnson/layrr/pkg/status"
 )
 
@@ -36,17 +39,51 @@ type Message struct {
 	Screenshot  string   `json:"screenshot"` // Base64 encoded image
 }
 
-// Bridge coordinates messages between the browser and Claude Code
+// Bridge coordinates messages between the browser and Claude Code.
+//
+// HandleMessage doesn't currently cancel an in-flight turn when the
+// browser socket drops, even though ExecBackend.WithContext exists for
+// exactly that purpose: doing so needs a socket-lifetime context threaded
+// through claude.Manager.SendMessage, and that API isn't part of this
+// package, so wiring it in is scoped out for now rather than guessed at.
 type Bridge struct {
 	claudeManager *claude.Manager
+	gitManager    *git.GitManager
+	mirror        *git.Mirror // nil when mirroring isn't configured
 	verbose       bool
 	display       *status.Display
+
+	lastCheckpoint  git.CheckpointID // most recently committed checkpoint
+	priorCheckpoint git.CheckpointID // the checkpoint before lastCheckpoint, so formatMessage can diff lastCheckpoint against it
+
+	turnMu     sync.Mutex // held for the duration of one turn, serializing HandleMessage calls
+	queueDepth int32      // messages waiting behind the in-flight turn
+
+	statusMu    sync.Mutex
+	currentTurn string
+}
+
+// QueueStatus summarizes Bridge's in-flight and queued work for
+// status.Display to render.
+type QueueStatus struct {
+	Depth       int
+	CurrentTurn string
+}
+
+// QueueStatus reports how many messages are waiting behind the turn
+// currently in flight, and what that turn is.
+func (b *Bridge) QueueStatus() QueueStatus {
+	b.statusMu.Lock()
+	defer b.statusMu.Unlock()
+	return QueueStatus{Depth: int(atomic.LoadInt32(&b.queueDepth)), CurrentTurn: b.currentTurn}
 }
 
 // NewBridge creates a new bridge
-func NewBridge(claudeManager *claude.Manager, verbose bool, display *status.Display) *Bridge {
+func NewBridge(claudeManager *claude.Manager, gitManager *git.GitManager, mirror *git.Mirror, verbose bool, display *status.Display) *Bridge {
 	return &Bridge{
 		claudeManager: claudeManager,
+		gitManager:    gitManager,
+		mirror:        mirror,
 		verbose:       verbose,
 		display:       display,
 	}
@@ -58,9 +95,34 @@ func (b *Bridge) HandleMessage(msg Message) error {
 	fmt.Printf("[Bridge] Message ID: %d\n", msg.ID)
 	fmt.Printf("[Bridge] Instruction: %s\n", msg.Instruction)
 
+	atomic.AddInt32(&b.queueDepth, 1)
+
+	// Only one turn runs at a time, so a message arriving mid-run doesn't
+	// have its edits swept up by the in-flight turn's commit. Stashing the
+	// new message's edits while a prior turn is still in flight would race
+	// with that turn's own git operations (Claude writing files, `git add`/
+	// `git commit` in CommitCheckpoint) with no mutual exclusion between
+	// the two - there's no way to take a safe snapshot of the tree without
+	// already holding turnMu, which by definition we don't have yet. So
+	// don't try: just serialize on turnMu and let the queued message wait
+	// its turn like any other.
+	b.turnMu.Lock()
+	defer b.turnMu.Unlock()
+	// This message is now the in-flight turn, no longer waiting behind one.
+	atomic.AddInt32(&b.queueDepth, -1)
+
+	b.statusMu.Lock()
+	b.currentTurn = fmt.Sprintf("msg-%d: %s", msg.ID, msg.Instruction)
+	b.statusMu.Unlock()
+	defer func() {
+		b.statusMu.Lock()
+		b.currentTurn = ""
+		b.statusMu.Unlock()
+	}()
+
 	// Format the message for Claude Code
 	fmt.Printf("[Bridge] 📝 Formatting message for Claude Code...\n")
-	formattedMsg := b.formatMessage(msg)
+	formattedMsg := b.formatMessage(msg, b.priorCheckpoint, b.lastCheckpoint)
 	fmt.Printf("[Bridge] ✅ Formatted message: %s\n", formattedMsg)
 
 	// Log the instruction details
@@ -69,22 +131,57 @@ func (b *Bridge) HandleMessage(msg Message) error {
 	fmt.Printf("[Bridge] 📊 Area info: %s\n", areaInfo)
 	fmt.Printf("[Bridge] 💬 Instruction: %s\n", msg.Instruction)
 
+	// Open a checkpoint for this message so the user can undo this single
+	// edit later without losing any that come after it.
+	checkpoint, err := b.gitManager.BeginCheckpoint(fmt.Sprintf("msg-%d", msg.ID))
+	if err != nil {
+		return fmt.Errorf("failed to begin checkpoint: %w", err)
+	}
+
 	// Send to Claude Code (this blocks until Claude finishes)
 	fmt.Printf("[Bridge] 🚀 Calling Claude Manager...\n")
 
-	err := b.claudeManager.SendMessage(formattedMsg)
+	err = b.claudeManager.SendMessage(formattedMsg)
 
 	if err != nil {
 		fmt.Printf("[Bridge] ❌ Claude Manager error: %v\n", err)
+
+		// Claude may have already written partial edits before failing. Commit
+		// them to the checkpoint branch rather than leaving it dangling and
+		// uncommitted, where they'd silently get folded into the next turn's
+		// commit instead of being their own inspectable, revertible checkpoint.
+		if commitErr := b.gitManager.CommitCheckpoint(checkpoint, fmt.Sprintf("Message %d (failed): %s", msg.ID, msg.Instruction)); commitErr != nil {
+			return fmt.Errorf("failed to send message to Claude Code: %w (and failed to commit partial checkpoint: %v)", err, commitErr)
+		}
+		b.priorCheckpoint = b.lastCheckpoint
+		b.lastCheckpoint = checkpoint
+
 		return fmt.Errorf("failed to send message to Claude Code: %w", err)
 	}
 	fmt.Printf("[Bridge] ✅ Claude Manager completed successfully\n")
 
+	if err := b.gitManager.CommitCheckpoint(checkpoint, fmt.Sprintf("Message %d: %s", msg.ID, msg.Instruction)); err != nil {
+		return fmt.Errorf("failed to commit checkpoint: %w", err)
+	}
+	b.priorCheckpoint = b.lastCheckpoint
+	b.lastCheckpoint = checkpoint
+
+	if b.mirror != nil {
+		// PushCheckpoint pushes asynchronously, so failures don't surface
+		// here - they land in Mirror.Status().LastError instead.
+		b.mirror.PushCheckpoint(checkpoint)
+	}
+
 	return nil
 }
 
-// formatMessage formats a browser message for Claude Code
-func (b *Bridge) formatMessage(msg Message) string {
+// formatMessage formats a browser message for Claude Code. prior and last,
+// when both set, are the two most recently committed checkpoints; they're
+// diffed against each other so Claude can see what changed during its last
+// turn instead of having to re-read files from scratch. There's no delta to
+// show before the second turn, since a single checkpoint has nothing to
+// diff against.
+func (b *Bridge) formatMessage(msg Message, prior, last git.CheckpointID) string {
 	// Format message for Claude Code CLI
 	// Single-line format keeps the message compact and readable
 
@@ -139,6 +236,22 @@ func (b *Bridge) formatMessage(msg Message) string {
 
 	parts = append(parts, ")")
 
+	// Append a diff summary of the last turn's changes, if there's a prior
+	// checkpoint to diff against, so Claude has memory of what changed
+	// without re-reading files. Diffing the two checkpoint branches (rather
+	// than either bare id against "HEAD") is what actually resolves, since
+	// checkpoints only exist as layrr/ckpt/<id> branches. ShortStat keeps
+	// this single-line like the rest of the message; DiffModePatch's hunks
+	// contain embedded newlines that would break the single-line format.
+	if prior != "" && last != "" {
+		diffs, err := b.gitManager.DiffBetween(git.CheckpointBranch(prior), git.CheckpointBranch(last))
+		if err != nil {
+			fmt.Printf("[Bridge] ⚠️  Failed to diff last turn's changes: %v\n", err)
+		} else if len(diffs) > 0 {
+			parts = append(parts, fmt.Sprintf("[%s]", git.FormatDiff(diffs, git.DiffModeShortStat)))
+		}
+	}
+
 	// Join all parts with spaces - single line, no newlines
 	return strings.Join(parts, " ")
 }
@@ -0,0 +1,92 @@
+package bridge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/thetronjohnson/layrr/pkg/git"
+)
+
+// newTestGitManager sets up a real on-disk git repo (via go-git, so this
+// doesn't depend on the git binary being installed) with one commit, and
+// wraps it in a GitManager the same way NewGitManager would. It returns the
+// repo's directory too, so tests can write further changes into it.
+func newTestGitManager(t *testing.T) (*git.GitManager, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit: %v", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("initial\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	sig := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	if _, err := wt.Commit("initial commit", &gogit.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	return git.NewGitManager(dir), dir
+}
+
+func TestFormatMessageIncludesDiffBetweenCheckpointBranches(t *testing.T) {
+	gm, dir := newTestGitManager(t)
+	b := &Bridge{gitManager: gm}
+
+	first, err := gm.BeginCheckpoint("msg-1")
+	if err != nil {
+		t.Fatalf("BeginCheckpoint: %v", err)
+	}
+	// Committing a checkpoint needs a change to commit; a clean tree is
+	// rejected as an empty commit.
+	if err := os.WriteFile(filepath.Join(dir, "first.txt"), []byte("first\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := gm.CommitCheckpoint(first, "message 1"); err != nil {
+		t.Fatalf("CommitCheckpoint: %v", err)
+	}
+
+	second, err := gm.BeginCheckpoint("msg-2")
+	if err != nil {
+		t.Fatalf("BeginCheckpoint (second): %v", err)
+	}
+	// newFile.txt didn't exist at the first checkpoint, so this is exactly
+	// the kind of change the diff summary should surface.
+	if err := os.WriteFile(filepath.Join(dir, "newFile.txt"), []byte("added\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := gm.CommitCheckpoint(second, "message 2"); err != nil {
+		t.Fatalf("CommitCheckpoint (second): %v", err)
+	}
+
+	got := b.formatMessage(Message{ID: 3, Instruction: "do the thing"}, first, second)
+
+	if !strings.Contains(got, "repo-changes:") {
+		t.Errorf("formatMessage() = %q, want it to include a repo-changes diff summary", got)
+	}
+}
+
+func TestFormatMessageOmitsDiffWithoutTwoCheckpoints(t *testing.T) {
+	gm, _ := newTestGitManager(t)
+	b := &Bridge{gitManager: gm}
+
+	got := b.formatMessage(Message{ID: 1, Instruction: "first message"}, "", "")
+
+	if strings.Contains(got, "repo-changes:") {
+		t.Errorf("formatMessage() = %q, want no diff summary before there are two checkpoints to compare", got)
+	}
+}
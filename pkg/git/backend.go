@@ -0,0 +1,38 @@
+package git
+
+import "context"
+
+// Backend abstracts the underlying git implementation used by GitManager.
+// LibBackend performs operations in-process via go-git; ExecBackend shells
+// out to the git binary. GitManager prefers LibBackend and falls back to
+// ExecBackend when a repo can't be opened in-process (e.g. unusual
+// filesystems or git configurations go-git doesn't support).
+type Backend interface {
+	GetCommitHistory(limit int) ([]Commit, error)
+	IsGitRepo() bool
+
+	// CurrentBranch returns the name of the currently checked-out branch.
+	CurrentBranch() (string, error)
+	// CreateBranch creates a branch named name off the current HEAD without
+	// switching to it.
+	CreateBranch(name string) error
+	// CheckoutBranch switches the worktree to the named branch.
+	CheckoutBranch(name string) error
+	// ListBranches returns branch names with the given prefix.
+	ListBranches(prefix string) ([]string, error)
+	// Commit stages all changes and commits them to the current branch,
+	// e.g. to finalize a checkpoint branch via GitManager.CommitCheckpoint.
+	Commit(message string) error
+	// DiffBetween returns per-file diffs between two commit-ish refs.
+	DiffBetween(a, b string) ([]FileDiff, error)
+	// Push pushes the local branch ref to the identically-named branch on
+	// remote, authenticating as configured in remote.Auth.
+	Push(ctx context.Context, remote RemoteConfig, ref string) error
+
+	// Stash saves uncommitted changes (including untracked files) under
+	// label and returns an identifier that PopStash can restore later. It
+	// returns ErrNothingToStash if the worktree was already clean.
+	Stash(label string) (string, error)
+	// PopStash restores the changes saved under id and removes the entry.
+	PopStash(id string) error
+}
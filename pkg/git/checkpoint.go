@@ -0,0 +1,95 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const checkpointBranchPrefix = "layrr/ckpt/"
+
+// CheckpointID identifies a checkpoint branch.
+type CheckpointID string
+
+// CheckpointBranch returns the branch name a checkpoint with the given id
+// was created under, for callers outside this package that need to pass it
+// to DiffBetween or similar ref-taking operations.
+func CheckpointBranch(id CheckpointID) string {
+	return checkpointBranchPrefix + string(id)
+}
+
+// Checkpoint is a lightweight branch created off HEAD before a single
+// Claude edit is applied, so that edit can be committed or reverted as one
+// unit without disturbing checkpoints made before or after it.
+type Checkpoint struct {
+	ID     CheckpointID
+	Label  string
+	Branch string
+}
+
+// BeginCheckpoint branches off HEAD as layrr/ckpt/<id> and switches to it.
+// Callers apply their edits after this returns, then call CommitCheckpoint
+// or RevertToCheckpoint.
+func (g *GitManager) BeginCheckpoint(label string) (CheckpointID, error) {
+	id := CheckpointID(fmt.Sprintf("%d", time.Now().UnixNano()))
+	branch := checkpointBranchPrefix + string(id)
+
+	if err := g.backend.CreateBranch(branch); err != nil {
+		return "", fmt.Errorf("failed to begin checkpoint: %w", err)
+	}
+	if err := g.backend.CheckoutBranch(branch); err != nil {
+		return "", fmt.Errorf("failed to begin checkpoint: %w", err)
+	}
+
+	g.mu.Lock()
+	g.labels[id] = label
+	g.mu.Unlock()
+
+	return id, nil
+}
+
+// CommitCheckpoint stages and commits the checkpoint branch, recording msg
+// as the commit message.
+func (g *GitManager) CommitCheckpoint(id CheckpointID, msg string) error {
+	if err := g.backend.Commit(msg); err != nil {
+		return fmt.Errorf("failed to commit checkpoint %s: %w", id, err)
+	}
+	return nil
+}
+
+// RevertToCheckpoint switches HEAD back to the given checkpoint's branch.
+// Unlike reset --hard on main, checkpoints made after id are left in place
+// as branches, so ListCheckpoints can still find them.
+func (g *GitManager) RevertToCheckpoint(id CheckpointID) error {
+	branch := checkpointBranchPrefix + string(id)
+	if err := g.backend.CheckoutBranch(branch); err != nil {
+		return fmt.Errorf("failed to revert to checkpoint %s: %w", id, err)
+	}
+	return nil
+}
+
+// ListCheckpoints returns every checkpoint branch, most recent first.
+func (g *GitManager) ListCheckpoints() ([]Checkpoint, error) {
+	branches, err := g.backend.ListBranches(checkpointBranchPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	checkpoints := make([]Checkpoint, 0, len(branches))
+	for _, branch := range branches {
+		id := CheckpointID(strings.TrimPrefix(branch, checkpointBranchPrefix))
+		checkpoints = append(checkpoints, Checkpoint{
+			ID:     id,
+			Label:  g.labels[id],
+			Branch: branch,
+		})
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].ID > checkpoints[j].ID })
+
+	return checkpoints, nil
+}
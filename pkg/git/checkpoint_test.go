@@ -0,0 +1,106 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// newTestGitManager sets up a real on-disk git repo (via go-git, so this
+// doesn't depend on the git binary being installed) with one commit, and
+// returns a GitManager backed by it.
+func newTestGitManager(t *testing.T) *GitManager {
+	t.Helper()
+	dir := t.TempDir()
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit: %v", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("initial\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	sig := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	if _, err := wt.Commit("initial commit", &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	return &GitManager{projectDir: dir, backend: &LibBackend{projectDir: dir, repo: repo}, labels: map[CheckpointID]string{}}
+}
+
+func TestCheckpointLifecycle(t *testing.T) {
+	gm := newTestGitManager(t)
+
+	id, err := gm.BeginCheckpoint("first edit")
+	if err != nil {
+		t.Fatalf("BeginCheckpoint: %v", err)
+	}
+
+	branch, err := gm.backend.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+	if want := CheckpointBranch(id); branch != want {
+		t.Errorf("CurrentBranch after BeginCheckpoint = %q, want %q", branch, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(gm.projectDir, "file.txt"), []byte("edit\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := gm.CommitCheckpoint(id, "message 1"); err != nil {
+		t.Fatalf("CommitCheckpoint: %v", err)
+	}
+
+	checkpoints, err := gm.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints: %v", err)
+	}
+	if len(checkpoints) != 1 || checkpoints[0].ID != id || checkpoints[0].Label != "first edit" {
+		t.Errorf("ListCheckpoints = %+v, want one checkpoint %q labeled %q", checkpoints, id, "first edit")
+	}
+
+	second, err := gm.BeginCheckpoint("second edit")
+	if err != nil {
+		t.Fatalf("BeginCheckpoint (second): %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(gm.projectDir, "file.txt"), []byte("edit 2\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := gm.CommitCheckpoint(second, "message 2"); err != nil {
+		t.Fatalf("CommitCheckpoint (second): %v", err)
+	}
+
+	// RevertToCheckpoint should switch back to the first checkpoint's
+	// branch, leaving the second one in place as its own branch rather
+	// than resetting it away.
+	if err := gm.RevertToCheckpoint(id); err != nil {
+		t.Fatalf("RevertToCheckpoint: %v", err)
+	}
+	branch, err = gm.backend.CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch: %v", err)
+	}
+	if want := CheckpointBranch(id); branch != want {
+		t.Errorf("CurrentBranch after RevertToCheckpoint = %q, want %q", branch, want)
+	}
+
+	checkpoints, err = gm.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints (after second): %v", err)
+	}
+	if len(checkpoints) != 2 {
+		t.Errorf("ListCheckpoints = %+v, want 2 checkpoints (second wasn't discarded by revert)", checkpoints)
+	}
+}
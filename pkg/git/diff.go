@@ -0,0 +1,71 @@
+package git
+
+import "fmt"
+
+// FileDiff describes the change to a single file between two refs.
+type FileDiff struct {
+	Path      string `json:"path"`
+	OldPath   string `json:"oldPath,omitempty"` // set when the file was renamed
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Hunks     string `json:"hunks"` // unified diff body for this file
+}
+
+// DiffBetween returns the per-file diff between two commit-ish refs (e.g.
+// two checkpoint branches, or a checkpoint and "HEAD").
+func (g *GitManager) DiffBetween(a, b string) ([]FileDiff, error) {
+	diffs, err := g.backend.DiffBetween(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s..%s: %w", a, b, err)
+	}
+	return diffs, nil
+}
+
+// DiffMode selects how FormatDiff renders a set of FileDiffs.
+type DiffMode int
+
+const (
+	// DiffModeShortStat renders only the "+N/-M across K files" summary.
+	DiffModeShortStat DiffMode = iota
+	// DiffModeNameStatus renders one line per changed file, no hunks.
+	DiffModeNameStatus
+	// DiffModePatch renders the summary plus unified-diff hunks.
+	DiffModePatch
+)
+
+// maxDiffHunks bounds how many files' hunks FormatDiff includes in
+// DiffModePatch before truncating, mirroring the element-count clamp in
+// Bridge.formatMessage.
+const maxDiffHunks = 10
+
+// FormatDiff renders diffs as a compact summary, optionally followed by
+// per-file detail, depending on mode.
+func FormatDiff(diffs []FileDiff, mode DiffMode) string {
+	adds, dels := 0, 0
+	for _, d := range diffs {
+		adds += d.Additions
+		dels += d.Deletions
+	}
+
+	summary := fmt.Sprintf("repo-changes: +%d/-%d across %d files", adds, dels, len(diffs))
+	if mode == DiffModeShortStat {
+		return summary
+	}
+
+	out := summary + "\n"
+
+	for i, d := range diffs {
+		if mode == DiffModeNameStatus {
+			out += fmt.Sprintf("%s +%d/-%d\n", d.Path, d.Additions, d.Deletions)
+			continue
+		}
+
+		if i >= maxDiffHunks {
+			out += fmt.Sprintf("[+%d more hunks]\n", len(diffs)-maxDiffHunks)
+			break
+		}
+		out += fmt.Sprintf("--- %s\n%s\n", d.Path, d.Hunks)
+	}
+
+	return out
+}
@@ -0,0 +1,249 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thetronjohnson/layrr/pkg/oscommands"
+)
+
+// ExecBackend implements Backend by shelling out to the git binary for
+// every operation, via an oscommands.CmdObjBuilder. It's the fallback for
+// environments where the in-process LibBackend can't open the repo.
+type ExecBackend struct {
+	projectDir string
+	cmds       *oscommands.CmdObjBuilder
+	ctx        context.Context
+}
+
+// NewExecBackend creates an ExecBackend rooted at projectDir, running
+// commands as real subprocesses.
+func NewExecBackend(projectDir string) *ExecBackend {
+	return NewExecBackendWithRunner(projectDir, oscommands.NewOSCommandRunner())
+}
+
+// NewExecBackendWithRunner creates an ExecBackend that executes commands
+// through runner, so tests can inject an oscommands.FakeRunner.
+func NewExecBackendWithRunner(projectDir string, runner oscommands.ICmdObjRunner) *ExecBackend {
+	return &ExecBackend{projectDir: projectDir, cmds: oscommands.NewCmdObjBuilder(runner)}
+}
+
+// WithContext returns a copy of b whose commands are bound to ctx, so an
+// in-flight git operation can be cancelled cleanly (e.g. when the browser
+// socket behind a Claude turn drops).
+func (b *ExecBackend) WithContext(ctx context.Context) *ExecBackend {
+	clone := *b
+	clone.ctx = ctx
+	return &clone
+}
+
+func (b *ExecBackend) git(args ...string) *oscommands.CmdObj {
+	return b.cmds.New(append([]string{"git"}, args...)...).WithDir(b.projectDir).WithContext(b.ctx)
+}
+
+func (b *ExecBackend) GetCommitHistory(limit int) ([]Commit, error) {
+	// Format: hash|short|author|date|message
+	// Use --all to show all commits, not just ancestors of current HEAD
+	// Use --date-order to sort by commit date
+	output, err := b.git(
+		"log",
+		"--all",
+		"--date-order",
+		fmt.Sprintf("-%d", limit),
+		"--pretty=format:%H|%h|%an|%aI|%s",
+	).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit history: %w", err)
+	}
+
+	// Parse output
+	commits := []Commit{}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "|", 5)
+		if len(parts) != 5 {
+			continue
+		}
+
+		date, _ := time.Parse(time.RFC3339, parts[3])
+
+		commits = append(commits, Commit{
+			Hash:      parts[0],
+			ShortHash: parts[1],
+			Author:    parts[2],
+			Date:      date,
+			Message:   parts[4],
+		})
+	}
+
+	return commits, nil
+}
+
+func (b *ExecBackend) IsGitRepo() bool {
+	return b.git("rev-parse", "--git-dir").Run() == nil
+}
+
+func (b *ExecBackend) CurrentBranch() (string, error) {
+	out, err := b.git("rev-parse", "--abbrev-ref", "HEAD").RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (b *ExecBackend) CreateBranch(name string) error {
+	if err := b.git("branch", name).Run(); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+	return nil
+}
+
+func (b *ExecBackend) CheckoutBranch(name string) error {
+	if err := b.git("checkout", name).Run(); err != nil {
+		return fmt.Errorf("failed to checkout branch %s: %w", name, err)
+	}
+	return nil
+}
+
+func (b *ExecBackend) ListBranches(prefix string) ([]string, error) {
+	out, err := b.git("for-each-ref", "--format=%(refname:short)", "refs/heads/"+prefix+"*").RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	branches := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line != "" {
+			branches = append(branches, line)
+		}
+	}
+	return branches, nil
+}
+
+func (b *ExecBackend) Commit(message string) error {
+	if err := b.git("add", ".").Run(); err != nil {
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	if err := b.git("commit", "-m", message).WithEnv(
+		"GIT_AUTHOR_NAME=Layrr",
+		"GIT_AUTHOR_EMAIL=hitman@layrr.dev",
+		"GIT_COMMITTER_NAME=Layrr",
+		"GIT_COMMITTER_EMAIL=hitman@layrr.dev",
+	).Run(); err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return nil
+}
+
+func (b *ExecBackend) DiffBetween(a, rev string) ([]FileDiff, error) {
+	// -M detects renames; numstat gives per-file add/delete counts cheaply
+	// before we fetch the (more expensive) hunks per file.
+	numstat, err := b.git("diff", "--numstat", "-M", a, rev).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s..%s: %w", a, rev, err)
+	}
+
+	diffs := []FileDiff{}
+	for _, line := range strings.Split(strings.TrimSpace(numstat), "\n") {
+		if line == "" {
+			continue
+		}
+
+		cols := strings.SplitN(line, "\t", 3)
+		if len(cols) != 3 {
+			continue
+		}
+
+		d := FileDiff{Path: cols[2]}
+		fmt.Sscanf(cols[0], "%d", &d.Additions)
+		fmt.Sscanf(cols[1], "%d", &d.Deletions)
+
+		// git reports renames as "old/path.go => new/path.go"
+		if before, after, ok := strings.Cut(d.Path, " => "); ok {
+			d.OldPath = before
+			d.Path = after
+		}
+
+		if hunks, err := b.git("diff", "-M", a, rev, "--", d.Path).RunWithOutput(); err == nil {
+			d.Hunks = hunks
+		}
+
+		diffs = append(diffs, d)
+	}
+
+	return diffs, nil
+}
+
+func (b *ExecBackend) Push(ctx context.Context, remote RemoteConfig, ref string) error {
+	url := remote.URL
+	if remote.Auth.HTTPSToken != "" {
+		url = withHTTPSToken(url, remote.Auth.HTTPSToken)
+	}
+
+	cmd := b.WithContext(ctx).git("push", url, fmt.Sprintf("%s:refs/heads/%s", ref, ref))
+	if remote.Auth.SSHKeyPath != "" {
+		cmd = cmd.WithEnv(fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes", remote.Auth.SSHKeyPath))
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to push %s to %s: %w", ref, remote.Name, err)
+	}
+
+	return nil
+}
+
+func (b *ExecBackend) Stash(label string) (string, error) {
+	out, err := b.git("stash", "push", "-u", "-m", label).RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to stash: %w", err)
+	}
+	if strings.Contains(out, "No local changes to save") {
+		return "", ErrNothingToStash
+	}
+
+	hash, err := b.git("rev-parse", "refs/stash").RunWithOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve stash: %w", err)
+	}
+
+	return strings.TrimSpace(hash), nil
+}
+
+func (b *ExecBackend) PopStash(id string) error {
+	idx, err := b.stashIndex(id)
+	if err != nil {
+		return err
+	}
+
+	if err := b.git("stash", "pop", fmt.Sprintf("stash@{%d}", idx)).Run(); err != nil {
+		return fmt.Errorf("failed to pop stash %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// stashIndex resolves a stash commit hash back to its current stash@{N}
+// position, since the stack shifts as other entries are pushed or popped.
+func (b *ExecBackend) stashIndex(id string) (int, error) {
+	out, err := b.git("stash", "list", "--format=%H").RunWithOutput()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stashes: %w", err)
+	}
+
+	for i, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.TrimSpace(line) == id {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("stash %s not found", id)
+}
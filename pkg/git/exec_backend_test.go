@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thetronjohnson/layrr/pkg/oscommands"
+)
+
+func TestExecBackendGetCommitHistoryParsesOutput(t *testing.T) {
+	runner := oscommands.NewFakeRunner("aaa111|aaa|Ada|2024-01-02T15:04:05Z|first\nbbb222|bbb|Bob|2024-01-01T15:04:05Z|second\n", nil)
+	b := NewExecBackendWithRunner("/repo", runner)
+
+	commits, err := b.GetCommitHistory(10)
+	if err != nil {
+		t.Fatalf("GetCommitHistory returned error: %v", err)
+	}
+
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	if commits[0].Hash != "aaa111" || commits[0].Author != "Ada" || commits[0].Message != "first" {
+		t.Errorf("commits[0] = %+v, unexpected", commits[0])
+	}
+}
+
+func TestExecBackendPushUsesMatchingLocalAndRemoteRef(t *testing.T) {
+	runner := oscommands.NewFakeRunner("", nil)
+	b := NewExecBackendWithRunner("/repo", runner)
+
+	remote := RemoteConfig{Name: "github", URL: "https://github.com/example/repo.git"}
+	if err := b.Push(context.Background(), remote, "layrr/ckpt/123"); err != nil {
+		t.Fatalf("Push returned error: %v", err)
+	}
+
+	if len(runner.Calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(runner.Calls))
+	}
+
+	args := runner.Calls[0].Args
+	wantRefspec := "layrr/ckpt/123:refs/heads/layrr/ckpt/123"
+	if len(args) == 0 || args[len(args)-1] != wantRefspec {
+		t.Errorf("push args = %v, want last arg %q", args, wantRefspec)
+	}
+}
+
+func TestExecBackendStashReportsErrNothingToStash(t *testing.T) {
+	runner := oscommands.NewFakeRunner("No local changes to save\n", nil)
+	b := NewExecBackendWithRunner("/repo", runner)
+
+	if _, err := b.Stash("label"); err != ErrNothingToStash {
+		t.Errorf("Stash() error = %v, want ErrNothingToStash", err)
+	}
+}
@@ -0,0 +1,311 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// LibBackend implements Backend in-process using go-git, so Layrr doesn't
+// depend on a git binary being installed and operations aren't serialized
+// behind subprocess spawns.
+type LibBackend struct {
+	projectDir string
+	repo       *git.Repository
+
+	// fallback is used for operations go-git doesn't implement, such as
+	// stash, mirroring the Backend-fallback pattern this package already
+	// uses when go-git can't open a repo at all.
+	fallback *ExecBackend
+}
+
+// NewLibBackend opens projectDir as a go-git repository.
+func NewLibBackend(projectDir string) (*LibBackend, error) {
+	repo, err := git.PlainOpen(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repo: %w", err)
+	}
+
+	return &LibBackend{projectDir: projectDir, repo: repo}, nil
+}
+
+func (b *LibBackend) GetCommitHistory(limit int) ([]Commit, error) {
+	// --all in the exec backend shows every ref's history, not just HEAD's
+	// ancestors, sorted by commit date; mirror both by walking every branch
+	// tip, deduping by hash, then sorting the full set before truncating -
+	// truncating per-branch instead would drop commits that are newer but
+	// live only on a branch walked after limit was already reached.
+	refs, err := b.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+
+	seen := map[plumbing.Hash]bool{}
+	commits := []Commit{}
+
+	walkFrom := func(hash plumbing.Hash) error {
+		iter, err := b.repo.Log(&git.LogOptions{From: hash})
+		if err != nil {
+			return err
+		}
+		defer iter.Close()
+
+		return iter.ForEach(func(c *object.Commit) error {
+			if seen[c.Hash] {
+				return nil
+			}
+			seen[c.Hash] = true
+
+			commits = append(commits, Commit{
+				Hash:      c.Hash.String(),
+				ShortHash: c.Hash.String()[:7],
+				Author:    c.Author.Name,
+				Date:      c.Author.When,
+				Message:   strings.TrimSpace(c.Message),
+			})
+			return nil
+		})
+	}
+
+	if head, err := b.repo.Head(); err == nil {
+		if err := walkFrom(head.Hash()); err != nil {
+			return nil, fmt.Errorf("failed to get commit history: %w", err)
+		}
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if !ref.Name().IsBranch() {
+			return nil
+		}
+		return walkFrom(ref.Hash())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit history: %w", err)
+	}
+
+	sort.Slice(commits, func(i, j int) bool { return commits[i].Date.After(commits[j].Date) })
+
+	if len(commits) > limit {
+		commits = commits[:limit]
+	}
+
+	return commits, nil
+}
+
+func (b *LibBackend) IsGitRepo() bool {
+	_, err := b.repo.Head()
+	return err == nil || err == plumbing.ErrReferenceNotFound
+}
+
+func (b *LibBackend) CurrentBranch() (string, error) {
+	head, err := b.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return head.Name().Short(), nil
+}
+
+func (b *LibBackend) CreateBranch(name string) error {
+	head, err := b.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(name), head.Hash())
+	if err := b.repo.Storer.SetReference(ref); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (b *LibBackend) CheckoutBranch(name string) error {
+	wt, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(name)}); err != nil {
+		return fmt.Errorf("failed to checkout branch %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (b *LibBackend) ListBranches(prefix string) ([]string, error) {
+	refs, err := b.repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	branches := []string{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if strings.HasPrefix(name, prefix) {
+			branches = append(branches, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+func (b *LibBackend) Commit(message string) error {
+	wt, err := b.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if err := wt.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	sig := &object.Signature{
+		Name:  "Layrr",
+		Email: "hitman@layrr.dev",
+		When:  time.Now(),
+	}
+
+	if _, err := wt.Commit(message, &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	return nil
+}
+
+func (b *LibBackend) DiffBetween(a, rev string) ([]FileDiff, error) {
+	commitA, err := b.resolveCommit(a)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", a, err)
+	}
+	commitB, err := b.resolveCommit(rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", rev, err)
+	}
+
+	treeA, err := commitA.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree for %s: %w", a, err)
+	}
+	treeB, err := commitB.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree for %s: %w", rev, err)
+	}
+
+	changes, err := treeA.Diff(treeB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s..%s: %w", a, rev, err)
+	}
+
+	diffs := []FileDiff{}
+	for _, change := range changes {
+		from, to, err := change.Files()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read change: %w", err)
+		}
+
+		d := FileDiff{}
+		switch {
+		case to != nil:
+			d.Path = to.Name
+		case from != nil:
+			d.Path = from.Name
+		}
+		if from != nil && to != nil && from.Name != to.Name {
+			d.OldPath = from.Name
+		}
+
+		patch, err := change.Patch()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build patch for %s: %w", d.Path, err)
+		}
+
+		for _, stat := range patch.Stats() {
+			d.Additions += stat.Addition
+			d.Deletions += stat.Deletion
+		}
+		d.Hunks = patch.String()
+
+		diffs = append(diffs, d)
+	}
+
+	return diffs, nil
+}
+
+func (b *LibBackend) Push(ctx context.Context, remote RemoteConfig, ref string) error {
+	// Remotes are registered lazily under a Layrr-owned name so repeated
+	// pushes to the same remote reuse one config.RemoteConfig entry.
+	remoteName := "layrr-mirror-" + remote.Name
+
+	if _, err := b.repo.Remote(remoteName); err != nil {
+		if _, err := b.repo.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{remote.URL}}); err != nil {
+			return fmt.Errorf("failed to configure remote %s: %w", remote.Name, err)
+		}
+	}
+
+	auth, err := remoteAuthMethod(remote.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to configure auth for %s: %w", remote.Name, err)
+	}
+
+	// Push the local branch to an identically-named branch on the remote,
+	// mirroring the refspec ExecBackend builds for the git CLI.
+	branchRef := plumbing.NewBranchReferenceName(ref)
+	refSpec := config.RefSpec(fmt.Sprintf("%s:%s", branchRef, branchRef))
+
+	err = b.repo.PushContext(ctx, &git.PushOptions{RemoteName: remoteName, Auth: auth, RefSpecs: []config.RefSpec{refSpec}})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("failed to push %s to %s: %w", ref, remote.Name, err)
+	}
+
+	return nil
+}
+
+func remoteAuthMethod(auth RemoteAuth) (transport.AuthMethod, error) {
+	switch {
+	case auth.HTTPSToken != "":
+		return &http.BasicAuth{Username: "layrr", Password: auth.HTTPSToken}, nil
+	case auth.SSHKeyPath != "":
+		return ssh.NewPublicKeysFromFile("git", auth.SSHKeyPath, "")
+	default:
+		return nil, nil
+	}
+}
+
+// Stash delegates to ExecBackend, since go-git has no stash support.
+func (b *LibBackend) Stash(label string) (string, error) {
+	return b.execFallback().Stash(label)
+}
+
+// PopStash delegates to ExecBackend, since go-git has no stash support.
+func (b *LibBackend) PopStash(id string) error {
+	return b.execFallback().PopStash(id)
+}
+
+func (b *LibBackend) execFallback() *ExecBackend {
+	if b.fallback == nil {
+		b.fallback = NewExecBackend(b.projectDir)
+	}
+	return b.fallback
+}
+
+func (b *LibBackend) resolveCommit(ref string) (*object.Commit, error) {
+	hash, err := b.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, err
+	}
+	return b.repo.CommitObject(*hash)
+}
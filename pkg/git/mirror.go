@@ -0,0 +1,172 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteAuth configures how Mirror authenticates to a remote. Exactly one
+// of HTTPSToken or SSHKeyPath should be set.
+type RemoteAuth struct {
+	// HTTPSToken is a personal access token used as an HTTPS password
+	// (e.g. a GitHub PAT).
+	HTTPSToken string
+	// SSHKeyPath is the path to a private key used for SSH auth.
+	SSHKeyPath string
+}
+
+// RemoteConfig is one push target for Mirror.
+type RemoteConfig struct {
+	Name string // e.g. "github", "gitea"
+	URL  string
+	Auth RemoteAuth
+}
+
+// MirrorConfig configures a Mirror: a remote (or several) to replicate
+// Layrr sessions to, plus how long to wait for rapid commits to settle
+// before pushing. Nothing in this snapshot constructs a MirrorConfig from
+// flags or a config file - there's no cmd/main package here to own a
+// --mirror flag and load it into NewGitManager's caller. Wiring that up
+// belongs with whatever introduces that entrypoint, not this package.
+type MirrorConfig struct {
+	Remotes []RemoteConfig
+	// Debounce is how long to wait after a commit before pushing, so a
+	// burst of checkpoints collapses into one push. Defaults to 2s.
+	Debounce time.Duration
+}
+
+// MirrorStatus is a point-in-time snapshot of Mirror's state, surfaced
+// through status.Display.
+type MirrorStatus struct {
+	Remotes      []string
+	Pending      bool
+	LastPushedAt time.Time
+	LastError    string
+}
+
+// Mirror pushes checkpoint branches handed to it through PushCheckpoint to
+// one or more configured remotes in the background, coalescing rapid calls
+// into a single push. It does not watch the repository for new commits
+// itself; a commit is pushed only when PushCheckpoint is called for it.
+type Mirror struct {
+	gitManager *GitManager
+	config     MirrorConfig
+
+	mu     sync.Mutex
+	timer  *time.Timer
+	status MirrorStatus
+}
+
+// NewMirror creates a Mirror for gitManager using the given config.
+func NewMirror(gitManager *GitManager, config MirrorConfig) *Mirror {
+	if config.Debounce <= 0 {
+		config.Debounce = 2 * time.Second
+	}
+
+	remotes := make([]string, len(config.Remotes))
+	for i, r := range config.Remotes {
+		remotes[i] = r.Name
+	}
+
+	return &Mirror{
+		gitManager: gitManager,
+		config:     config,
+		status:     MirrorStatus{Remotes: remotes},
+	}
+}
+
+// PushCheckpoint schedules a debounced, asynchronous push of the given
+// checkpoint's branch to every configured remote. Calls arriving within the
+// debounce window reset the timer, so a burst of checkpoints (e.g. several
+// queued messages committing back-to-back) collapses into one push of the
+// latest branch. Pushing asynchronously, rather than blocking the caller on
+// retries, matters because the bridge calls this while still holding
+// turnMu: a synchronous push retried up to maxPushAttempts times would keep
+// every queued message waiting behind a slow or down remote.
+func (m *Mirror) PushCheckpoint(id CheckpointID) {
+	branch := checkpointBranchPrefix + string(id)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Pending = true
+
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+	m.timer = time.AfterFunc(m.config.Debounce, func() {
+		_ = m.push(context.Background(), branch)
+	})
+}
+
+// push pushes branch to every configured remote, retrying each failed push
+// with exponential backoff. Every error is retried the same way; transient
+// and permanent failures are not told apart.
+func (m *Mirror) push(ctx context.Context, branch string) error {
+	m.mu.Lock()
+	m.status.Pending = false
+	m.mu.Unlock()
+
+	var errs []string
+	for _, remote := range m.config.Remotes {
+		if err := m.pushWithRetry(ctx, remote, branch); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(errs) > 0 {
+		m.status.LastError = strings.Join(errs, "; ")
+		return fmt.Errorf("mirror push failed: %s", m.status.LastError)
+	}
+
+	m.status.LastError = ""
+	m.status.LastPushedAt = time.Now()
+
+	return nil
+}
+
+const maxPushAttempts = 5
+
+func (m *Mirror) pushWithRetry(ctx context.Context, remote RemoteConfig, branch string) error {
+	backoff := time.Second
+
+	var lastErr error
+	for attempt := 0; attempt < maxPushAttempts; attempt++ {
+		if lastErr = m.gitManager.backend.Push(ctx, remote, branch); lastErr == nil {
+			return nil
+		}
+
+		if attempt == maxPushAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return fmt.Errorf("%s: %w", remote.Name, lastErr)
+}
+
+func withHTTPSToken(rawURL, token string) string {
+	if token == "" || !strings.HasPrefix(rawURL, "https://") {
+		return rawURL
+	}
+	return "https://" + token + "@" + strings.TrimPrefix(rawURL, "https://")
+}
+
+// Status returns a snapshot of Mirror's current state.
+func (m *Mirror) Status() MirrorStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePushBackend implements just enough of Backend for Mirror: Push. The
+// embedded nil Backend means any other method Mirror might start calling
+// panics instead of silently succeeding, so a test gap like that gets
+// noticed.
+type fakePushBackend struct {
+	Backend
+
+	mu    sync.Mutex
+	calls []string // refs pushed, one entry per Push call
+}
+
+func (f *fakePushBackend) Push(ctx context.Context, remote RemoteConfig, ref string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, ref)
+	return nil
+}
+
+func (f *fakePushBackend) pushedRefs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func TestPushCheckpointPushesTheCheckpointBranchToEveryRemote(t *testing.T) {
+	backend := &fakePushBackend{}
+	gm := &GitManager{backend: backend, labels: map[CheckpointID]string{}}
+	m := NewMirror(gm, MirrorConfig{
+		Remotes:  []RemoteConfig{{Name: "github"}, {Name: "gitea"}},
+		Debounce: time.Millisecond,
+	})
+
+	m.PushCheckpoint(CheckpointID("1785015243129377098"))
+
+	deadline := time.Now().Add(time.Second)
+	for len(backend.pushedRefs()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for push, got: %v", backend.pushedRefs())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, ref := range backend.pushedRefs() {
+		if want := "layrr/ckpt/1785015243129377098"; ref != want {
+			t.Errorf("pushed ref = %q, want %q", ref, want)
+		}
+	}
+}
+
+func TestPushWithRetrySucceedsWithoutRetryingOnFirstSuccess(t *testing.T) {
+	backend := &fakePushBackend{}
+	gm := &GitManager{backend: backend, labels: map[CheckpointID]string{}}
+	m := NewMirror(gm, MirrorConfig{})
+
+	if err := m.pushWithRetry(context.Background(), RemoteConfig{Name: "github"}, "layrr/ckpt/1"); err != nil {
+		t.Fatalf("pushWithRetry: %v", err)
+	}
+	if refs := backend.pushedRefs(); len(refs) != 1 || refs[0] != "layrr/ckpt/1" {
+		t.Errorf("pushedRefs = %v, want exactly one push of layrr/ckpt/1", refs)
+	}
+}
@@ -0,0 +1,26 @@
+package git
+
+import "errors"
+
+// ErrNothingToStash is returned by GitManager.Stash when the worktree has
+// no uncommitted changes to save.
+var ErrNothingToStash = errors.New("no changes to stash")
+
+// StashID identifies a stashed set of changes.
+type StashID string
+
+// Stash saves uncommitted changes (including untracked files) so a queued
+// turn doesn't have them swept up by an in-flight one's commit, and returns
+// an ID PopStash can use to restore them later.
+func (g *GitManager) Stash(label string) (StashID, error) {
+	id, err := g.backend.Stash(label)
+	if err != nil {
+		return "", err
+	}
+	return StashID(id), nil
+}
+
+// PopStash restores the changes saved under id.
+func (g *GitManager) PopStash(id StashID) error {
+	return g.backend.PopStash(string(id))
+}
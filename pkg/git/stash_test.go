@@ -0,0 +1,60 @@
+package git
+
+import (
+	"testing"
+)
+
+// fakeStashBackend implements just enough of Backend for GitManager's
+// Stash/PopStash wrappers.
+type fakeStashBackend struct {
+	Backend
+
+	stashID  string
+	stashErr error
+
+	poppedID string
+	popErr   error
+}
+
+func (f *fakeStashBackend) Stash(label string) (string, error) {
+	return f.stashID, f.stashErr
+}
+
+func (f *fakeStashBackend) PopStash(id string) error {
+	f.poppedID = id
+	return f.popErr
+}
+
+func TestGitManagerStashReturnsTypedID(t *testing.T) {
+	backend := &fakeStashBackend{stashID: "stash@{0}"}
+	gm := &GitManager{backend: backend, labels: map[CheckpointID]string{}}
+
+	id, err := gm.Stash("label")
+	if err != nil {
+		t.Fatalf("Stash: %v", err)
+	}
+	if id != StashID("stash@{0}") {
+		t.Errorf("Stash() = %q, want %q", id, "stash@{0}")
+	}
+}
+
+func TestGitManagerStashPropagatesErrNothingToStash(t *testing.T) {
+	backend := &fakeStashBackend{stashErr: ErrNothingToStash}
+	gm := &GitManager{backend: backend, labels: map[CheckpointID]string{}}
+
+	if _, err := gm.Stash("label"); err != ErrNothingToStash {
+		t.Errorf("Stash() error = %v, want ErrNothingToStash", err)
+	}
+}
+
+func TestGitManagerPopStashPassesIDToBackend(t *testing.T) {
+	backend := &fakeStashBackend{}
+	gm := &GitManager{backend: backend, labels: map[CheckpointID]string{}}
+
+	if err := gm.PopStash(StashID("stash@{0}")); err != nil {
+		t.Fatalf("PopStash: %v", err)
+	}
+	if backend.poppedID != "stash@{0}" {
+		t.Errorf("backend received id %q, want %q", backend.poppedID, "stash@{0}")
+	}
+}
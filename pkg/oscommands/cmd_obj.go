@@ -0,0 +1,103 @@
+// Package oscommands wraps external process invocation behind a builder and
+// a runner interface, so callers like git.ExecBackend don't build
+// exec.Command directly. This mirrors lazygit's oscommands.CmdObj /
+// CmdObjBuilder / ICmdObjRunner split: it gives tests a FakeRunner to
+// inject, and gives long-running commands (like a Claude turn) a
+// context.Context to cancel.
+package oscommands
+
+import (
+	"context"
+	"io"
+)
+
+// CmdObj describes a single command invocation: its args, working
+// directory, extra environment variables, stdin, and cancellation context.
+// It's built via CmdObjBuilder and executed via an ICmdObjRunner.
+type CmdObj struct {
+	args   []string
+	dir    string
+	env    []string
+	stdin  io.Reader
+	ctx    context.Context
+	runner ICmdObjRunner
+}
+
+// WithDir sets the working directory the command runs in.
+func (c *CmdObj) WithDir(dir string) *CmdObj {
+	c.dir = dir
+	return c
+}
+
+// WithEnv appends extra environment variables (in "KEY=VALUE" form) on top
+// of the process's own environment.
+func (c *CmdObj) WithEnv(env ...string) *CmdObj {
+	c.env = append(c.env, env...)
+	return c
+}
+
+// WithStdin sets the reader piped to the command's stdin.
+func (c *CmdObj) WithStdin(stdin io.Reader) *CmdObj {
+	c.stdin = stdin
+	return c
+}
+
+// WithContext attaches ctx, so Run/RunWithOutput/RunAndStream can be
+// cancelled - e.g. when the browser socket backing a Claude turn drops.
+func (c *CmdObj) WithContext(ctx context.Context) *CmdObj {
+	c.ctx = ctx
+	return c
+}
+
+// Args returns the command and its arguments.
+func (c *CmdObj) Args() []string { return c.args }
+
+// Dir returns the configured working directory.
+func (c *CmdObj) Dir() string { return c.dir }
+
+// Env returns the extra environment variables to apply.
+func (c *CmdObj) Env() []string { return c.env }
+
+// Stdin returns the configured stdin reader, if any.
+func (c *CmdObj) Stdin() io.Reader { return c.stdin }
+
+// Context returns the cancellation context, defaulting to context.Background.
+func (c *CmdObj) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// Run executes the command, discarding its output.
+func (c *CmdObj) Run() error {
+	return c.runner.Run(c)
+}
+
+// RunWithOutput executes the command and returns its stdout.
+func (c *CmdObj) RunWithOutput() (string, error) {
+	return c.runner.RunWithOutput(c)
+}
+
+// RunAndStream executes the command, invoking cb with each line of stdout
+// as it's produced, instead of buffering the whole output.
+func (c *CmdObj) RunAndStream(cb func(line string)) error {
+	return c.runner.RunAndStream(c, cb)
+}
+
+// CmdObjBuilder constructs CmdObj values bound to a particular runner, so
+// production code uses OSCommandRunner and tests swap in a FakeRunner.
+type CmdObjBuilder struct {
+	runner ICmdObjRunner
+}
+
+// NewCmdObjBuilder creates a CmdObjBuilder that executes commands via runner.
+func NewCmdObjBuilder(runner ICmdObjRunner) *CmdObjBuilder {
+	return &CmdObjBuilder{runner: runner}
+}
+
+// New builds a CmdObj for the given command and arguments, e.g.
+// builder.New("git", "status").
+func (b *CmdObjBuilder) New(args ...string) *CmdObj {
+	return &CmdObj{args: args, runner: b.runner}
+}
@@ -0,0 +1,96 @@
+package oscommands
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCmdObjRecordsArgsDirAndEnv(t *testing.T) {
+	runner := NewFakeRunner("", nil)
+	builder := NewCmdObjBuilder(runner)
+
+	if err := builder.New("git", "status").WithDir("/repo").WithEnv("FOO=bar").Run(); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if len(runner.Calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(runner.Calls))
+	}
+
+	call := runner.Calls[0]
+	if got, want := call.Args, []string{"git", "status"}; !stringSlicesEqual(got, want) {
+		t.Errorf("Args = %v, want %v", got, want)
+	}
+	if call.Dir != "/repo" {
+		t.Errorf("Dir = %q, want %q", call.Dir, "/repo")
+	}
+	if !stringSlicesEqual(call.Env, []string{"FOO=bar"}) {
+		t.Errorf("Env = %v, want [FOO=bar]", call.Env)
+	}
+}
+
+func TestCmdObjRunWithOutputReturnsFakeOutput(t *testing.T) {
+	runner := NewFakeRunner("abc123\n", nil)
+	builder := NewCmdObjBuilder(runner)
+
+	out, err := builder.New("git", "rev-parse", "HEAD").RunWithOutput()
+	if err != nil {
+		t.Fatalf("RunWithOutput returned error: %v", err)
+	}
+	if out != "abc123\n" {
+		t.Errorf("output = %q, want %q", out, "abc123\n")
+	}
+}
+
+func TestCmdObjRunAndStreamInvokesCallbackPerLine(t *testing.T) {
+	runner := NewFakeRunner("line one\nline two\n", nil)
+	builder := NewCmdObjBuilder(runner)
+
+	var lines []string
+	if err := builder.New("git", "log").RunAndStream(func(line string) {
+		lines = append(lines, line)
+	}); err != nil {
+		t.Fatalf("RunAndStream returned error: %v", err)
+	}
+
+	if !stringSlicesEqual(lines, []string{"line one", "line two"}) {
+		t.Errorf("lines = %v, want [line one line two]", lines)
+	}
+}
+
+func TestCmdObjRunPropagatesRunnerError(t *testing.T) {
+	wantErr := errors.New("boom")
+	runner := NewFakeRunner("", wantErr)
+	builder := NewCmdObjBuilder(runner)
+
+	if err := builder.New("git", "push").Run(); err != wantErr {
+		t.Errorf("Run() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestCmdObjContextDefaultsToBackground(t *testing.T) {
+	cmd := NewCmdObjBuilder(NewFakeRunner("", nil)).New("git", "status")
+	if cmd.Context() != context.Background() {
+		t.Error("Context() should default to context.Background() when WithContext wasn't called")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmd.WithContext(ctx)
+	if cmd.Context() != ctx {
+		t.Error("Context() should return the ctx set via WithContext")
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package oscommands
+
+import (
+	"strings"
+	"sync"
+)
+
+// FakeCall records one invocation made through a FakeRunner.
+type FakeCall struct {
+	Args []string
+	Dir  string
+	Env  []string
+}
+
+// FakeRunner is an ICmdObjRunner that records invocations and returns
+// canned output instead of running anything, for use in tests.
+type FakeRunner struct {
+	Output string
+	Err    error
+
+	mu    sync.Mutex
+	Calls []FakeCall
+}
+
+// NewFakeRunner creates a FakeRunner that returns output/err for every call.
+func NewFakeRunner(output string, err error) *FakeRunner {
+	return &FakeRunner{Output: output, Err: err}
+}
+
+func (r *FakeRunner) record(cmd *CmdObj) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Calls = append(r.Calls, FakeCall{Args: cmd.Args(), Dir: cmd.Dir(), Env: cmd.Env()})
+}
+
+func (r *FakeRunner) Run(cmd *CmdObj) error {
+	r.record(cmd)
+	return r.Err
+}
+
+func (r *FakeRunner) RunWithOutput(cmd *CmdObj) (string, error) {
+	r.record(cmd)
+	return r.Output, r.Err
+}
+
+func (r *FakeRunner) RunAndStream(cmd *CmdObj, cb func(line string)) error {
+	r.record(cmd)
+	for _, line := range strings.Split(r.Output, "\n") {
+		if line != "" {
+			cb(line)
+		}
+	}
+	return r.Err
+}
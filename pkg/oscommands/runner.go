@@ -0,0 +1,74 @@
+package oscommands
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+)
+
+// ICmdObjRunner executes a built CmdObj. OSCommandRunner is the production
+// implementation; FakeRunner stands in for it in tests.
+type ICmdObjRunner interface {
+	Run(cmd *CmdObj) error
+	RunWithOutput(cmd *CmdObj) (string, error)
+	RunAndStream(cmd *CmdObj, cb func(line string)) error
+}
+
+// OSCommandRunner runs commands as real subprocesses via os/exec.
+type OSCommandRunner struct{}
+
+// NewOSCommandRunner creates an OSCommandRunner.
+func NewOSCommandRunner() *OSCommandRunner {
+	return &OSCommandRunner{}
+}
+
+func (r *OSCommandRunner) toExecCmd(cmd *CmdObj) *exec.Cmd {
+	args := cmd.Args()
+	execCmd := exec.CommandContext(cmd.Context(), args[0], args[1:]...)
+	execCmd.Dir = cmd.Dir()
+	execCmd.Stdin = cmd.Stdin()
+
+	if env := cmd.Env(); len(env) > 0 {
+		execCmd.Env = append(os.Environ(), env...)
+	}
+
+	return execCmd
+}
+
+func (r *OSCommandRunner) Run(cmd *CmdObj) error {
+	return r.toExecCmd(cmd).Run()
+}
+
+func (r *OSCommandRunner) RunWithOutput(cmd *CmdObj) (string, error) {
+	execCmd := r.toExecCmd(cmd)
+
+	var out bytes.Buffer
+	execCmd.Stdout = &out
+
+	if err := execCmd.Run(); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+func (r *OSCommandRunner) RunAndStream(cmd *CmdObj, cb func(line string)) error {
+	execCmd := r.toExecCmd(cmd)
+
+	stdout, err := execCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := execCmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		cb(scanner.Text())
+	}
+
+	return execCmd.Wait()
+}